@@ -0,0 +1,220 @@
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// EncoderFormat selects the wire format a core's encoder produces.
+type EncoderFormat int
+
+const (
+	// JSON is zap's standard JSON encoding (ZapJsonEncoder). It is the default.
+	JSON EncoderFormat = iota
+	// Console is the human-readable, colorized format used for local development.
+	Console
+	// Logfmt emits `key=value` pairs, for pipelines built around logfmt.
+	Logfmt
+	// GCP emits JSON shaped for Stackdriver/Cloud Logging ingestion.
+	GCP
+)
+
+func newEncoder(format EncoderFormat) zapcore.Encoder {
+	switch format {
+	case Console:
+		return zapConsoleEncoder()
+	case Logfmt:
+		return newLogfmtEncoder()
+	case GCP:
+		return newGCPEncoder()
+	default:
+		return ZapJsonEncoder()
+	}
+}
+
+var logfmtBufferPool = buffer.NewPool()
+
+// logfmtEncoder emits `key=value` pairs, quoting values that contain spaces
+// or an `=`. It delegates field storage to zapcore.MapObjectEncoder so
+// nested objects/arrays are supported, and renders fields in sorted key
+// order for deterministic output.
+type logfmtEncoder struct {
+	*zapcore.MapObjectEncoder
+}
+
+func newLogfmtEncoder() zapcore.Encoder {
+	return &logfmtEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder()}
+}
+
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range e.MapObjectEncoder.Fields {
+		clone.Fields[k] = v
+	}
+	return &logfmtEncoder{MapObjectEncoder: clone}
+}
+
+func (e *logfmtEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	final := e.Clone().(*logfmtEncoder)
+	for _, f := range fields {
+		f.AddTo(final.MapObjectEncoder)
+	}
+
+	line := logfmtBufferPool.Get()
+
+	writePair := func(key string, val interface{}) {
+		if line.Len() > 0 {
+			line.AppendByte(' ')
+		}
+		line.AppendString(key)
+		line.AppendByte('=')
+		line.AppendString(logfmtQuote(val))
+	}
+
+	ec := zapBaseEncoderConfig()
+	writePair(ec.TimeKey, entry.Time.Format(time.RFC3339Nano))
+	writePair(ec.LevelKey, entry.Level.String())
+	if entry.LoggerName != "" {
+		writePair(ec.NameKey, entry.LoggerName)
+	}
+	if entry.Caller.Defined {
+		writePair(ec.CallerKey, entry.Caller.String())
+	}
+	writePair(ec.MessageKey, entry.Message)
+	if entry.Stack != "" {
+		writePair(ec.StacktraceKey, entry.Stack)
+	}
+
+	keys := make([]string, 0, len(final.Fields))
+	for k := range final.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writePair(k, final.Fields[k])
+	}
+
+	line.AppendString(zapcore.DefaultLineEnding)
+
+	return line, nil
+}
+
+func logfmtQuote(val interface{}) string {
+	s := fmt.Sprint(val)
+	if s == "" {
+		return `""`
+	}
+	if logfmtNeedsQuote(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// logfmtNeedsQuote reports whether s must be quoted to stay on one logfmt
+// line and survive a naive `key=value` parse: besides the obvious
+// delimiters, any control character (newlines from stack traces or verbose
+// messages in particular) would otherwise corrupt the one-record-per-line
+// format.
+func logfmtNeedsQuote(s string) bool {
+	if strings.ContainsAny(s, " =\"") {
+		return true
+	}
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// gcpTraceKey and gcpSpanKey are the well-known Stackdriver attributes that
+// correlate a log entry with a trace/span. WithTraceContext tags entries with
+// plain "traceId"/"spanId" fields; gcpEncoder promotes them on the way out.
+const (
+	gcpTraceField = "traceId"
+	gcpSpanField  = "spanId"
+
+	gcpTraceKey = "logging.googleapis.com/trace"
+	gcpSpanKey  = "logging.googleapis.com/spanId"
+)
+
+// gcpEncoder wraps the JSON encoder with Stackdriver's field conventions:
+// level -> severity (using Stackdriver's string values), an RFC3339Nano
+// "timestamp", and trace/span fields promoted to their reserved keys.
+//
+// The rename has to happen on two separate paths: fields passed directly to
+// a log call arrive through EncodeEntry, but fields attached via
+// logger.With(...) (as WithTraceContext does) are written straight into the
+// wrapped JSON encoder's buffer by zapcore's ioCore.With, through the
+// promoted ObjectEncoder methods, before EncodeEntry ever runs. gcpEncoder
+// therefore overrides AddString (the only method WithTraceContext's
+// zap.String fields exercise) to rename on the way in, in addition to
+// EncodeEntry's post-processing of per-call fields.
+type gcpEncoder struct {
+	zapcore.Encoder
+}
+
+func newGCPEncoder() zapcore.Encoder {
+	ec := zapBaseEncoderConfig()
+	ec.LevelKey = "severity"
+	ec.TimeKey = "timestamp"
+	ec.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	ec.EncodeLevel = func(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString(gcpSeverity(level))
+	}
+	return &gcpEncoder{Encoder: zapcore.NewJSONEncoder(ec)}
+}
+
+func (e *gcpEncoder) Clone() zapcore.Encoder {
+	return &gcpEncoder{Encoder: e.Encoder.Clone()}
+}
+
+// AddString intercepts fields added via logger.With(...) (e.g. by
+// WithTraceContext) so traceId/spanId are promoted even when they never pass
+// through EncodeEntry's fields argument.
+func (e *gcpEncoder) AddString(key, value string) {
+	e.Encoder.AddString(gcpPromoteKey(key), value)
+}
+
+func (e *gcpEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	promoted := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		f.Key = gcpPromoteKey(f.Key)
+		promoted[i] = f
+	}
+	return e.Encoder.EncodeEntry(entry, promoted)
+}
+
+func gcpPromoteKey(key string) string {
+	switch key {
+	case gcpTraceField:
+		return gcpTraceKey
+	case gcpSpanField:
+		return gcpSpanKey
+	default:
+		return key
+	}
+}
+
+func gcpSeverity(level zapcore.Level) string {
+	switch level {
+	case zapcore.DebugLevel:
+		return "DEBUG"
+	case zapcore.InfoLevel:
+		return "INFO"
+	case zapcore.WarnLevel:
+		return "WARNING"
+	case zapcore.ErrorLevel:
+		return "ERROR"
+	case zapcore.DPanicLevel, zapcore.PanicLevel, zapcore.FatalLevel:
+		return "CRITICAL"
+	default:
+		return "DEFAULT"
+	}
+}
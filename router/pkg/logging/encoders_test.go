@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type memorySink struct {
+	*bytes.Buffer
+}
+
+func (memorySink) Sync() error { return nil }
+
+func TestLogfmtEncoderEscapesControlCharacters(t *testing.T) {
+	enc := newLogfmtEncoder()
+
+	entry := zapcore.Entry{Level: zapcore.InfoLevel, Time: time.Now(), Message: "boom"}
+	buf, err := enc.EncodeEntry(entry, []zapcore.Field{zap.String("stack", "line1\nline2")})
+	if err != nil {
+		t.Fatalf("EncodeEntry: %v", err)
+	}
+
+	out := strings.TrimRight(buf.String(), "\n")
+	if strings.Count(out, "\n") != 0 {
+		t.Fatalf("logfmt output must be one line, got %q", out)
+	}
+	if !strings.Contains(out, `stack="line1\nline2"`) {
+		t.Fatalf("expected the newline to be escaped within a quoted value, got %q", out)
+	}
+}
+
+func TestLogfmtEncoderIncludesEntryStacktrace(t *testing.T) {
+	enc := newLogfmtEncoder()
+
+	entry := zapcore.Entry{
+		Level:   zapcore.ErrorLevel,
+		Time:    time.Now(),
+		Message: "boom",
+		Stack:   "main.boom()\n\t/app/main.go:42",
+	}
+	buf, err := enc.EncodeEntry(entry, nil)
+	if err != nil {
+		t.Fatalf("EncodeEntry: %v", err)
+	}
+
+	out := strings.TrimRight(buf.String(), "\n")
+	if !strings.Contains(out, `stacktrace="main.boom()`) {
+		t.Fatalf("expected entry.Stack to be rendered under stacktrace=, got %q", out)
+	}
+}
+
+func TestGCPEncoderPromotesTraceContextAttachedViaWith(t *testing.T) {
+	enc := newGCPEncoder()
+	buf := &bytes.Buffer{}
+	core := zapcore.NewCore(enc, memorySink{buf}, zapcore.DebugLevel)
+
+	// Mirrors how WithTraceContext is used: traceId/spanId attached via With,
+	// not passed directly to the log call.
+	core = core.With([]zapcore.Field{
+		zap.String("traceId", "trace-123"),
+		zap.String("spanId", "span-456"),
+	})
+
+	logger := zap.New(core)
+	logger.Info("hello")
+
+	out := buf.String()
+	if !strings.Contains(out, `"logging.googleapis.com/trace":"trace-123"`) {
+		t.Fatalf("expected trace id to be promoted, got %q", out)
+	}
+	if !strings.Contains(out, `"logging.googleapis.com/spanId":"span-456"`) {
+		t.Fatalf("expected span id to be promoted, got %q", out)
+	}
+	if strings.Contains(out, `"traceId"`) || strings.Contains(out, `"spanId"`) {
+		t.Fatalf("raw traceId/spanId keys should not appear, got %q", out)
+	}
+}
@@ -2,14 +2,15 @@ package logging
 
 import (
 	"fmt"
-	"log"
 	"math"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 const (
@@ -18,16 +19,135 @@ const (
 
 type RequestIDKey struct{}
 
-func New(prettyLogging bool, debug bool, levelOutput zapcore.Level, fileOutput string, fileLevel zapcore.Level) *zap.Logger {
-	cores := []zapcore.Core{newZapOutputLogger(prettyLogging, levelOutput)}
+// FileConfig controls log rotation for the file sink, backed by lumberjack.
+// Zero values fall back to lumberjack's own defaults (e.g. a 100MB MaxSize).
+type FileConfig struct {
+	// MaxSize is the maximum size in megabytes of the log file before it gets rotated.
+	MaxSize int
+	// MaxBackups is the maximum number of old, rotated log files to retain.
+	MaxBackups int
+	// MaxAge is the maximum number of days to retain old, rotated log files.
+	MaxAge int
+	// Compress determines if rotated log files should be compressed using gzip.
+	Compress bool
+	// LocalTime determines if the timestamps in rotated file names use the host's
+	// local time instead of UTC.
+	LocalTime bool
+}
+
+// Logger bundles the zap logger with the resources backing its cores (e.g. the
+// lumberjack file rotator, or an OTLP exporter attached via AddCore) and the
+// Manager controlling their levels, so callers can flush/release resources on
+// shutdown and adjust verbosity at runtime.
+type Logger struct {
+	*zap.Logger
+	*Manager
+
+	cores *lockedMultiCore
+
+	closersMu sync.Mutex
+	closers   map[string]func() error
+}
+
+// AddCore attaches a new named sink (e.g. an OTLP log exporter) to the logger
+// without rebuilding it. It replaces any existing core of the same name,
+// closing that core's previously registered closer first. closer may be nil
+// if the core owns no resource that needs releasing.
+//
+// AddCore does not register the core with the Manager, since the core's
+// level is normally baked in at construction time: build it against a
+// zap.AtomicLevel obtained from manager.NewAtomicLevel(name, level) first if
+// it should be adjustable at runtime like the built-in stdout/file cores.
+func (l *Logger) AddCore(name string, core zapcore.Core, closer func() error) {
+	l.closeAndSetCloser(name, closer)
+	l.cores.AddCore(name, core)
+}
+
+// RemoveCore detaches the named sink, closing its registered closer (if any),
+// and reports whether it was present.
+func (l *Logger) RemoveCore(name string) bool {
+	removed := l.cores.RemoveCore(name)
+	l.closeAndSetCloser(name, nil)
+	return removed
+}
+
+// ReplaceCore swaps the named sink for a new one, adding it if it didn't
+// already exist, and closes the replaced core's previously registered closer
+// (if any). closer may be nil if the new core owns no resource that needs
+// releasing.
+func (l *Logger) ReplaceCore(name string, core zapcore.Core, closer func() error) {
+	l.closeAndSetCloser(name, closer)
+	l.cores.ReplaceCore(name, core)
+}
+
+// closeAndSetCloser replaces the closer registered under name with closer
+// (removing the entry if closer is nil), closing whatever was registered
+// there before. Errors from closing the old resource are logged rather than
+// returned, since callers are adding/replacing/removing a core, not closing
+// the Logger itself.
+func (l *Logger) closeAndSetCloser(name string, closer func() error) {
+	l.closersMu.Lock()
+	old, hadOld := l.closers[name]
+	if closer != nil {
+		l.closers[name] = closer
+	} else {
+		delete(l.closers, name)
+	}
+	l.closersMu.Unlock()
+
+	if hadOld {
+		if err := old(); err != nil {
+			l.Logger.Error("logging: failed to close core", zap.String("core", name), zap.Error(err))
+		}
+	}
+}
+
+// Close flushes the logger and closes every resource opened on its behalf,
+// including cores attached later via AddCore/ReplaceCore.
+func (l *Logger) Close() error {
+	var err error
+	if syncErr := l.Logger.Sync(); syncErr != nil {
+		err = syncErr
+	}
+
+	l.closersMu.Lock()
+	closers := make([]func() error, 0, len(l.closers))
+	for _, closer := range l.closers {
+		closers = append(closers, closer)
+	}
+	l.closersMu.Unlock()
+
+	for _, closer := range closers {
+		if closeErr := closer(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func New(outputFormat EncoderFormat, debug bool, levelOutput zapcore.Level, fileOutput string, fileFormat EncoderFormat, fileLevel zapcore.Level, fileConfig FileConfig) *Logger {
+	manager := newManager()
+
+	outputLevel := manager.NewAtomicLevel("stdout", levelOutput)
+	cores := map[string]zapcore.Core{"stdout": newZapOutputLogger(outputFormat, outputLevel)}
+
+	closers := make(map[string]func() error)
 	if fileOutput != "" {
-		cores = append(cores, newZapFileLogger(fileOutput, fileLevel))
+		fileLevelAtomic := manager.NewAtomicLevel("file", fileLevel)
+
+		fileCore, fileCloser := newZapFileLogger(fileOutput, fileLevelAtomic, fileConfig, fileFormat)
+		cores["file"] = fileCore
+		closers["file"] = fileCloser
 	}
 
-	return newZapLogger(
-		debug,
-		cores...,
-	)
+	multiCore := newLockedMultiCore(cores)
+
+	return &Logger{
+		Logger:  newZapLogger(debug, multiCore),
+		Manager: manager,
+		cores:   multiCore,
+		closers: closers,
+	}
 }
 
 func zapBaseEncoderConfig() zapcore.EncoderConfig {
@@ -76,43 +196,37 @@ func attachBaseFields(core zapcore.Core) zapcore.Core {
 	return core
 }
 
-func newZapFileLogger(file string, level zapcore.Level) zapcore.Core {
-	fileOpen, closer, err := zap.Open(file)
-	if err != nil && closer != nil {
-		closer()
-	}
-	if err != nil {
-		log.Fatalf("could not open log file: %s\n", err)
+func newZapFileLogger(file string, level zapcore.LevelEnabler, fileConfig FileConfig, format EncoderFormat) (zapcore.Core, func() error) {
+	rotator := &lumberjack.Logger{
+		Filename:   file,
+		MaxSize:    fileConfig.MaxSize,
+		MaxBackups: fileConfig.MaxBackups,
+		MaxAge:     fileConfig.MaxAge,
+		Compress:   fileConfig.Compress,
+		LocalTime:  fileConfig.LocalTime,
 	}
 
-	core := zapcore.NewCore(ZapJsonEncoder(), fileOpen, level)
+	core := zapcore.NewCore(newEncoder(format), zapcore.AddSync(rotator), level)
 
-	return attachBaseFields(core)
+	return attachBaseFields(core), rotator.Close
 }
 
-func newZapOutputLogger(prettyLogging bool, level zapcore.Level) zapcore.Core {
-	var encoder zapcore.Encoder
-	if prettyLogging {
-		encoder = zapConsoleEncoder()
-	} else {
-		encoder = ZapJsonEncoder()
-	}
-
+func newZapOutputLogger(format EncoderFormat, level zapcore.LevelEnabler) zapcore.Core {
 	syncer := zapcore.AddSync(os.Stdout)
 
 	baseCore := zapcore.NewCore(
-		encoder,
+		newEncoder(format),
 		syncer,
 		level,
 	)
-	if !prettyLogging {
+	if format != Console {
 		baseCore = attachBaseFields(baseCore)
 	}
 
 	return baseCore
 }
 
-func newZapLogger(debug bool, cores ...zapcore.Core) *zap.Logger {
+func newZapLogger(debug bool, core zapcore.Core) *zap.Logger {
 	var zapOpts []zap.Option
 
 	if debug {
@@ -121,10 +235,7 @@ func newZapLogger(debug bool, cores ...zapcore.Core) *zap.Logger {
 
 	zapOpts = append(zapOpts, zap.AddStacktrace(zap.ErrorLevel))
 
-	zapTee := zapcore.NewTee(cores...)
-	zapLogger := zap.New(zapTee, zapOpts...)
-
-	return zapLogger
+	return zap.New(core, zapOpts...)
 }
 
 func ZapLogLevelFromString(logLevel string) (zapcore.Level, error) {
@@ -0,0 +1,38 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewWritesToFileAndCloseReleasesRotator(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "router.log")
+
+	logger := New(JSON, false, zapcore.InfoLevel, file, JSON, zapcore.InfoLevel, FileConfig{
+		MaxSize:    1,
+		MaxBackups: 2,
+		MaxAge:     1,
+		Compress:   true,
+		LocalTime:  true,
+	})
+
+	logger.Info("hello")
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("expected the file sink to create %s: %v", file, err)
+	}
+	if len(contents) == 0 {
+		t.Fatalf("expected %s to contain the logged line, got an empty file", file)
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Manager tracks the zap.AtomicLevel backing each core of a Logger, keyed by
+// core name (e.g. "stdout", "file"), so operators can read or change levels
+// at runtime without restarting the process.
+type Manager struct {
+	mu     sync.RWMutex
+	levels map[string]zap.AtomicLevel
+}
+
+func newManager() *Manager {
+	return &Manager{
+		levels: make(map[string]zap.AtomicLevel),
+	}
+}
+
+// register stores level under core on the manager.
+func (m *Manager) register(core string, level zap.AtomicLevel) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.levels[core] = level
+}
+
+// NewAtomicLevel creates an AtomicLevel seeded at level, registers it under
+// core, and returns it so the caller can build a zapcore.Core against it
+// (e.g. zapcore.NewCore(encoder, ws, level)). Cores attached later via
+// Logger.AddCore/ReplaceCore are not wired into the Manager automatically —
+// since a core's level is fixed at construction time, call this first and
+// build the core with the returned level if it should be adjustable at
+// runtime like the built-in "stdout"/"file" cores.
+func (m *Manager) NewAtomicLevel(core string, level zapcore.Level) zap.AtomicLevel {
+	atomicLevel := zap.NewAtomicLevelAt(level)
+	m.register(core, atomicLevel)
+	return atomicLevel
+}
+
+// Level returns the AtomicLevel for the named core, if one has been registered.
+func (m *Manager) Level(core string) (zap.AtomicLevel, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	level, ok := m.levels[core]
+	return level, ok
+}
+
+// ServeHTTP handles GET and PUT requests for a core's log level, mirroring
+// zap's AtomicLevel.ServeHTTP. The target core is selected via the "core"
+// query parameter (e.g. "?core=stdout" vs "?core=file") and defaults to
+// "stdout" when omitted.
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	core := r.URL.Query().Get("core")
+	if core == "" {
+		core = "stdout"
+	}
+
+	level, ok := m.Level(core)
+	if !ok {
+		http.Error(w, fmt.Sprintf("logging: unknown core %q", core), http.StatusNotFound)
+		return
+	}
+
+	level.ServeHTTP(w, r)
+}
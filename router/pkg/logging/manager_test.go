@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestManagerLevelRoundTripsThroughNewAtomicLevel(t *testing.T) {
+	manager := newManager()
+
+	if _, ok := manager.Level("stdout"); ok {
+		t.Fatalf("expected no level to be registered before NewAtomicLevel")
+	}
+
+	atomicLevel := manager.NewAtomicLevel("stdout", zapcore.WarnLevel)
+
+	level, ok := manager.Level("stdout")
+	if !ok {
+		t.Fatalf("expected a level to be registered for %q after NewAtomicLevel", "stdout")
+	}
+	if level.Level() != zapcore.WarnLevel {
+		t.Fatalf("expected the registered level to start at %v, got %v", zapcore.WarnLevel, level.Level())
+	}
+
+	atomicLevel.SetLevel(zapcore.ErrorLevel)
+	if level.Level() != zapcore.ErrorLevel {
+		t.Fatalf("expected Level() to return the same AtomicLevel returned by NewAtomicLevel, got %v", level.Level())
+	}
+}
+
+func TestManagerServeHTTPUnknownCoreReturns404(t *testing.T) {
+	manager := newManager()
+	manager.NewAtomicLevel("stdout", zapcore.InfoLevel)
+
+	req := httptest.NewRequest(http.MethodGet, "/?core=otlp", nil)
+	rw := httptest.NewRecorder()
+	manager.ServeHTTP(rw, req)
+
+	if rw.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown core, got %d", rw.Code)
+	}
+}
+
+func TestManagerServeHTTPGetPutRoundTripsAndDefaultsToStdout(t *testing.T) {
+	manager := newManager()
+	manager.NewAtomicLevel("stdout", zapcore.InfoLevel)
+
+	getReq := httptest.NewRequest(http.MethodGet, "/", nil)
+	getRW := httptest.NewRecorder()
+	manager.ServeHTTP(getRW, getReq)
+
+	if getRW.Code != http.StatusOK {
+		t.Fatalf("expected 200 from GET with no ?core=, got %d", getRW.Code)
+	}
+	if !strings.Contains(getRW.Body.String(), "info") {
+		t.Fatalf("expected the default-to-stdout GET to report the stdout level, got %q", getRW.Body.String())
+	}
+
+	putReq := httptest.NewRequest(http.MethodPut, "/?core=stdout", strings.NewReader(`{"level":"error"}`))
+	putRW := httptest.NewRecorder()
+	manager.ServeHTTP(putRW, putReq)
+
+	if putRW.Code != http.StatusOK {
+		t.Fatalf("expected 200 from PUT ?core=stdout, got %d", putRW.Code)
+	}
+
+	level, ok := manager.Level("stdout")
+	if !ok {
+		t.Fatalf("expected the stdout level to still be registered")
+	}
+	if level.Level() != zapcore.ErrorLevel {
+		t.Fatalf("expected the PUT to change the stdout level to error, got %v", level.Level())
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/wundergraph/cosmo/router/pkg/logging"
+)
+
+// Gin returns a Gin-compatible variant of RequestLogger, using gin.Context's
+// own ResponseWriter to read back the status code and bytes written.
+func Gin(logger *zap.Logger, opts Options) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		reqID := requestID(c.Request.Header)
+		reqLogger := logger.With(logging.WithRequestID(reqID))
+
+		ctx := context.WithValue(c.Request.Context(), logging.RequestIDKey{}, reqID)
+		ctx = context.WithValue(ctx, loggerContextKey{}, reqLogger)
+		c.Request = c.Request.WithContext(ctx)
+
+		defer func() {
+			status := c.Writer.Status()
+
+			if rvr := recover(); rvr != nil {
+				reqLogger.Error("panic while handling request",
+					zap.Any("error", rvr),
+					zap.ByteString("stacktrace", debug.Stack()),
+				)
+				c.AbortWithStatus(http.StatusInternalServerError)
+				status = http.StatusInternalServerError
+			}
+
+			fields := accessLogFields(c.Request.Method, c.Request.URL.Path, c.ClientIP(), c.Request.UserAgent(), status, time.Since(start), c.Request.ContentLength, c.Writer.Size())
+			logAccess(reqLogger, opts, status, fields)
+		}()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,162 @@
+// Package middleware provides structured HTTP access logging, replacing the
+// ad-hoc access logging scattered across the router.
+package middleware
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/wundergraph/cosmo/router/pkg/logging"
+)
+
+// Options configures RequestLogger and Gin.
+type Options struct {
+	// SampleRate is the fraction of successful (2xx) responses that get
+	// logged, from 0 (none, the zero value) to 1 (all). 4xx responses are
+	// always logged at WARN and 5xx at ERROR regardless of SampleRate.
+	//
+	// The zero value means "log no successful responses" rather than "log
+	// everything" — use DefaultOptions, or set SampleRate: 1 explicitly, to
+	// log every 2xx response.
+	SampleRate float64
+}
+
+// DefaultOptions logs every request, including successful ones.
+var DefaultOptions = Options{SampleRate: 1}
+
+func (o Options) sample() bool {
+	return o.SampleRate >= 1 || rand.Float64() < o.SampleRate
+}
+
+type loggerContextKey struct{}
+
+// FromContext returns the request-scoped logger stashed by RequestLogger or
+// Gin, pre-tagged with the request's reqId. It falls back to
+// zap's global logger if no request logger was attached to ctx.
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerContextKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return zap.L()
+}
+
+// requestID returns the caller-supplied request id, if any, otherwise a new one.
+func requestID(header http.Header) string {
+	if reqID := header.Get("X-Request-Id"); reqID != "" {
+		return reqID
+	}
+	return uuid.NewString()
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func accessLogFields(method, path, clientIP, userAgent string, status int, latency time.Duration, bytesIn int64, bytesOut int) []zap.Field {
+	return []zap.Field{
+		zap.String("method", method),
+		zap.String("path", path),
+		zap.Int("status", status),
+		{Key: "latency", Type: zapcore.DurationType, Integer: int64(latency)},
+		zap.String("client_ip", clientIP),
+		zap.String("user_agent", userAgent),
+		zap.Int64("bytes_in", bytesIn),
+		zap.Int("bytes_out", bytesOut),
+	}
+}
+
+func logAccess(logger *zap.Logger, opts Options, status int, fields []zap.Field) {
+	switch {
+	case status >= http.StatusInternalServerError:
+		logger.Error("request", fields...)
+	case status >= http.StatusBadRequest:
+		logger.Warn("request", fields...)
+	default:
+		if opts.sample() {
+			logger.Info("request", fields...)
+		}
+	}
+}
+
+// statusRecorder wraps http.ResponseWriter to capture the status code and
+// byte count written by downstream handlers. status defaults to 200, since
+// that's what net/http itself sends if a handler returns without calling
+// WriteHeader or Write.
+type statusRecorder struct {
+	http.ResponseWriter
+	status       int
+	bytesWritten int
+	written      bool
+}
+
+func newStatusRecorder(w http.ResponseWriter) *statusRecorder {
+	return &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (w *statusRecorder) WriteHeader(code int) {
+	if w.written {
+		return
+	}
+	w.written = true
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.written = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytesWritten += n
+	return n, err
+}
+
+// RequestLogger returns middleware that injects a request-scoped logger into
+// the request context and emits one structured access log entry per request.
+// Successful (2xx) responses are sampled per Options.SampleRate; 4xx/5xx
+// responses are always logged, and panics are recovered and logged at ERROR
+// with a stacktrace. A 500 response is sent only if the handler had not yet
+// written anything; otherwise the response already started is left as is.
+func RequestLogger(logger *zap.Logger, opts Options) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			reqID := requestID(r.Header)
+			reqLogger := logger.With(logging.WithRequestID(reqID))
+
+			ctx := context.WithValue(r.Context(), logging.RequestIDKey{}, reqID)
+			ctx = context.WithValue(ctx, loggerContextKey{}, reqLogger)
+			r = r.WithContext(ctx)
+
+			rec := newStatusRecorder(w)
+
+			defer func() {
+				if rvr := recover(); rvr != nil {
+					reqLogger.Error("panic while handling request",
+						zap.Any("error", rvr),
+						zap.ByteString("stacktrace", debug.Stack()),
+					)
+					if !rec.written {
+						http.Error(rec, "internal server error", http.StatusInternalServerError)
+					}
+				}
+
+				fields := accessLogFields(r.Method, r.URL.Path, clientIP(r), r.UserAgent(), rec.status, time.Since(start), r.ContentLength, rec.bytesWritten)
+				logAccess(reqLogger, opts, rec.status, fields)
+			}()
+
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
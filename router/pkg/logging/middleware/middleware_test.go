@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/wundergraph/cosmo/router/pkg/logging"
+)
+
+func TestRequestLoggerCapturesImplicit200(t *testing.T) {
+	logger, logs := logging.NewObserver()
+	handler := RequestLogger(logger, DefaultOptions)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Returns without calling Write/WriteHeader, like net/http's implicit 200.
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	if status := entries[0].ContextMap()["status"]; status != int64(http.StatusOK) {
+		t.Fatalf("expected status 200 to be logged, got %v", status)
+	}
+}
+
+func TestRequestLoggerSampleRateZeroSkipsSuccess(t *testing.T) {
+	logger, logs := logging.NewObserver()
+	handler := RequestLogger(logger, Options{SampleRate: 0})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	if entries := logs.All(); len(entries) != 0 {
+		t.Fatalf("expected no log entries with SampleRate 0, got %d", len(entries))
+	}
+}
+
+func TestRequestLoggerAlwaysLogsServerErrors(t *testing.T) {
+	logger, logs := logging.NewObserver()
+	handler := RequestLogger(logger, Options{SampleRate: 0})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected a log entry for a 5xx response regardless of SampleRate, got %d", len(entries))
+	}
+	if entries[0].Level != zapcore.ErrorLevel {
+		t.Fatalf("expected ERROR level for a 5xx response, got %v", entries[0].Level)
+	}
+}
+
+func TestRequestLoggerRecoversPanics(t *testing.T) {
+	logger, logs := logging.NewObserver()
+	handler := RequestLogger(logger, DefaultOptions)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	if rw.Code != http.StatusInternalServerError {
+		t.Fatalf("expected the recovered panic to produce a 500 response, got %d", rw.Code)
+	}
+
+	for _, entry := range logs.All() {
+		if entry.Message == "panic while handling request" {
+			return
+		}
+	}
+	t.Fatalf("expected a panic log entry, got %v", logs.All())
+}
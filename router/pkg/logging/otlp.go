@@ -0,0 +1,235 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type otlpConfig struct {
+	http     bool
+	insecure bool
+	headers  map[string]string
+	resource *resource.Resource
+	level    zapcore.LevelEnabler
+}
+
+// OTLPOption configures NewOTLPCore.
+type OTLPOption func(*otlpConfig)
+
+// WithOTLPHTTP exports over OTLP/HTTP instead of the default OTLP/gRPC.
+func WithOTLPHTTP() OTLPOption {
+	return func(c *otlpConfig) { c.http = true }
+}
+
+// WithOTLPInsecure disables transport security, for collectors reachable
+// without TLS (e.g. a sidecar on localhost).
+func WithOTLPInsecure() OTLPOption {
+	return func(c *otlpConfig) { c.insecure = true }
+}
+
+// WithOTLPHeaders attaches static headers (e.g. auth tokens) to every export request.
+func WithOTLPHeaders(headers map[string]string) OTLPOption {
+	return func(c *otlpConfig) { c.headers = headers }
+}
+
+// WithOTLPResource sets the resource (service.name, etc.) attached to every
+// exported log record. Defaults to resource.Default().
+func WithOTLPResource(res *resource.Resource) OTLPOption {
+	return func(c *otlpConfig) { c.resource = res }
+}
+
+// WithOTLPLevel sets the minimum level the core accepts. Defaults to
+// DebugLevel. Pass a zap.AtomicLevel obtained from Manager.NewAtomicLevel to
+// make this core's level adjustable at runtime through the same HTTP handler
+// that controls the stdout/file cores.
+func WithOTLPLevel(level zapcore.LevelEnabler) OTLPOption {
+	return func(c *otlpConfig) { c.level = level }
+}
+
+// NewOTLPCore returns a zapcore.Core that batches entries and exports them via
+// OTLP to endpoint, so cosmo's router and controlplane can ship logs to any
+// OTel collector alongside their existing traces and metrics.
+//
+// The returned closer shuts down the underlying LoggerProvider, flushing
+// pending batches and releasing the exporter's connection; it must be passed
+// to Logger.AddCore/ReplaceCore (or otherwise called on shutdown) or the
+// batch processor's goroutine and connection will leak. The core's own
+// Sync() only force-flushes pending entries — it does not shut the provider
+// down, since the core may still be written to after a Sync.
+func NewOTLPCore(endpoint string, opts ...OTLPOption) (zapcore.Core, func() error, error) {
+	cfg := &otlpConfig{level: zapcore.DebugLevel}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	exporter, err := newOTLPExporter(endpoint, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("logging: create otlp log exporter: %w", err)
+	}
+
+	res := cfg.resource
+	if res == nil {
+		res = resource.Default()
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+		sdklog.WithResource(res),
+	)
+
+	core := &otlpCore{
+		LevelEnabler: cfg.level,
+		provider:     provider,
+		logger:       provider.Logger("cosmo/logging"),
+	}
+
+	closer := func() error {
+		return provider.Shutdown(context.Background())
+	}
+
+	return core, closer, nil
+}
+
+func newOTLPExporter(endpoint string, cfg *otlpConfig) (sdklog.Exporter, error) {
+	ctx := context.Background()
+
+	if cfg.http {
+		httpOpts := []otlploghttp.Option{otlploghttp.WithEndpoint(endpoint)}
+		if cfg.insecure {
+			httpOpts = append(httpOpts, otlploghttp.WithInsecure())
+		}
+		if len(cfg.headers) > 0 {
+			httpOpts = append(httpOpts, otlploghttp.WithHeaders(cfg.headers))
+		}
+		return otlploghttp.New(ctx, httpOpts...)
+	}
+
+	grpcOpts := []otlploggrpc.Option{otlploggrpc.WithEndpoint(endpoint)}
+	if cfg.insecure {
+		grpcOpts = append(grpcOpts, otlploggrpc.WithInsecure())
+	}
+	if len(cfg.headers) > 0 {
+		grpcOpts = append(grpcOpts, otlploggrpc.WithHeaders(cfg.headers))
+	}
+	return otlploggrpc.New(ctx, grpcOpts...)
+}
+
+// otlpCore adapts an OTel Logs SDK logger to zapcore.Core.
+type otlpCore struct {
+	zapcore.LevelEnabler
+
+	provider *sdklog.LoggerProvider
+	logger   otellog.Logger
+	fields   []zapcore.Field
+}
+
+func (c *otlpCore) With(fields []zapcore.Field) zapcore.Core {
+	return &otlpCore{
+		LevelEnabler: c.LevelEnabler,
+		provider:     c.provider,
+		logger:       c.logger,
+		fields:       append(append([]zapcore.Field{}, c.fields...), fields...),
+	}
+}
+
+func (c *otlpCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *otlpCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	record := otellog.Record{}
+	record.SetTimestamp(entry.Time)
+	record.SetObservedTimestamp(entry.Time)
+	record.SetSeverity(otelSeverityFromZap(entry.Level))
+	record.SetSeverityText(entry.Level.String())
+	record.SetBody(otellog.StringValue(entry.Message))
+
+	for _, f := range c.fields {
+		record.AddAttributes(otellog.KeyValue{Key: f.Key, Value: otelValueFromZapField(f)})
+	}
+	for _, f := range fields {
+		record.AddAttributes(otellog.KeyValue{Key: f.Key, Value: otelValueFromZapField(f)})
+	}
+
+	c.logger.Emit(context.Background(), record)
+	return nil
+}
+
+func (c *otlpCore) Sync() error {
+	return c.provider.ForceFlush(context.Background())
+}
+
+// otelValueFromZapField mirrors zap's own field-type switch (see
+// zapcore.Field.AddTo) to avoid reflecting on f.Interface where possible.
+func otelValueFromZapField(f zapcore.Field) otellog.Value {
+	switch f.Type {
+	case zapcore.BoolType:
+		return otellog.BoolValue(f.Integer == 1)
+	case zapcore.DurationType:
+		return otellog.Int64Value(int64(time.Duration(f.Integer)))
+	case zapcore.Float64Type:
+		return otellog.Float64Value(math.Float64frombits(uint64(f.Integer)))
+	case zapcore.Float32Type:
+		return otellog.Float64Value(float64(math.Float32frombits(uint32(f.Integer))))
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+		return otellog.Int64Value(f.Integer)
+	case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+		return otellog.Int64Value(f.Integer)
+	case zapcore.StringType:
+		return otellog.StringValue(f.String)
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			return otellog.StringValue(err.Error())
+		}
+		return otellog.StringValue(fmt.Sprint(f.Interface))
+	default:
+		return otellog.StringValue(fmt.Sprint(f.Interface))
+	}
+}
+
+func otelSeverityFromZap(level zapcore.Level) otellog.Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return otellog.SeverityDebug
+	case zapcore.InfoLevel:
+		return otellog.SeverityInfo
+	case zapcore.WarnLevel:
+		return otellog.SeverityWarn
+	case zapcore.ErrorLevel:
+		return otellog.SeverityError
+	case zapcore.DPanicLevel, zapcore.PanicLevel:
+		return otellog.SeverityFatal1
+	case zapcore.FatalLevel:
+		return otellog.SeverityFatal
+	default:
+		return otellog.SeverityInfo
+	}
+}
+
+// WithTraceContext returns a child logger carrying the traceId/spanId found on
+// ctx, if any, so cores such as the OTLP core can correlate log entries with
+// the active span. It is a no-op when ctx carries no valid span context.
+func WithTraceContext(logger *zap.Logger, ctx context.Context) *zap.Logger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return logger
+	}
+	return logger.With(
+		zap.String("traceId", spanContext.TraceID().String()),
+		zap.String("spanId", spanContext.SpanID().String()),
+	)
+}
@@ -0,0 +1,150 @@
+package logging
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestOtelValueFromZapField(t *testing.T) {
+	tests := []struct {
+		name  string
+		field zapcore.Field
+		want  otellog.Value
+	}{
+		{
+			name:  "bool true",
+			field: zapcore.Field{Type: zapcore.BoolType, Integer: 1},
+			want:  otellog.BoolValue(true),
+		},
+		{
+			name:  "bool false",
+			field: zapcore.Field{Type: zapcore.BoolType, Integer: 0},
+			want:  otellog.BoolValue(false),
+		},
+		{
+			name:  "duration",
+			field: zapcore.Field{Type: zapcore.DurationType, Integer: int64(2 * time.Second)},
+			want:  otellog.Int64Value(int64(2 * time.Second)),
+		},
+		{
+			name:  "float64",
+			field: zapcore.Field{Type: zapcore.Float64Type, Integer: int64(math.Float64bits(3.5))},
+			want:  otellog.Float64Value(3.5),
+		},
+		{
+			name:  "float32",
+			field: zapcore.Field{Type: zapcore.Float32Type, Integer: int64(math.Float32bits(1.5))},
+			want:  otellog.Float64Value(1.5),
+		},
+		{
+			name:  "int64",
+			field: zapcore.Field{Type: zapcore.Int64Type, Integer: 42},
+			want:  otellog.Int64Value(42),
+		},
+		{
+			name:  "int32",
+			field: zapcore.Field{Type: zapcore.Int32Type, Integer: 42},
+			want:  otellog.Int64Value(42),
+		},
+		{
+			name:  "int16",
+			field: zapcore.Field{Type: zapcore.Int16Type, Integer: 42},
+			want:  otellog.Int64Value(42),
+		},
+		{
+			name:  "int8",
+			field: zapcore.Field{Type: zapcore.Int8Type, Integer: 42},
+			want:  otellog.Int64Value(42),
+		},
+		{
+			name:  "uint64",
+			field: zapcore.Field{Type: zapcore.Uint64Type, Integer: 42},
+			want:  otellog.Int64Value(42),
+		},
+		{
+			name:  "uint32",
+			field: zapcore.Field{Type: zapcore.Uint32Type, Integer: 42},
+			want:  otellog.Int64Value(42),
+		},
+		{
+			name:  "uint16",
+			field: zapcore.Field{Type: zapcore.Uint16Type, Integer: 42},
+			want:  otellog.Int64Value(42),
+		},
+		{
+			name:  "uint8",
+			field: zapcore.Field{Type: zapcore.Uint8Type, Integer: 42},
+			want:  otellog.Int64Value(42),
+		},
+		{
+			name:  "string",
+			field: zapcore.Field{Type: zapcore.StringType, String: "hello"},
+			want:  otellog.StringValue("hello"),
+		},
+		{
+			name:  "error",
+			field: zapcore.Field{Type: zapcore.ErrorType, Interface: errors.New("boom")},
+			want:  otellog.StringValue("boom"),
+		},
+		{
+			name:  "default falls back to fmt.Sprint of Interface",
+			field: zapcore.Field{Type: zapcore.SkipType, Interface: 7},
+			want:  otellog.StringValue("7"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := otelValueFromZapField(tt.field)
+			if got.Kind() != tt.want.Kind() {
+				t.Fatalf("otelValueFromZapField(%+v).Kind() = %v, want %v", tt.field, got.Kind(), tt.want.Kind())
+			}
+			switch tt.want.Kind() {
+			case otellog.KindBool:
+				if got.AsBool() != tt.want.AsBool() {
+					t.Fatalf("otelValueFromZapField(%+v) = %v, want %v", tt.field, got.AsBool(), tt.want.AsBool())
+				}
+			case otellog.KindInt64:
+				if got.AsInt64() != tt.want.AsInt64() {
+					t.Fatalf("otelValueFromZapField(%+v) = %v, want %v", tt.field, got.AsInt64(), tt.want.AsInt64())
+				}
+			case otellog.KindFloat64:
+				if got.AsFloat64() != tt.want.AsFloat64() {
+					t.Fatalf("otelValueFromZapField(%+v) = %v, want %v", tt.field, got.AsFloat64(), tt.want.AsFloat64())
+				}
+			case otellog.KindString:
+				if got.AsString() != tt.want.AsString() {
+					t.Fatalf("otelValueFromZapField(%+v) = %q, want %q", tt.field, got.AsString(), tt.want.AsString())
+				}
+			default:
+				t.Fatalf("unexpected want.Kind() %v in test table", tt.want.Kind())
+			}
+		})
+	}
+}
+
+func TestOtelSeverityFromZap(t *testing.T) {
+	tests := []struct {
+		level zapcore.Level
+		want  otellog.Severity
+	}{
+		{zapcore.DebugLevel, otellog.SeverityDebug},
+		{zapcore.InfoLevel, otellog.SeverityInfo},
+		{zapcore.WarnLevel, otellog.SeverityWarn},
+		{zapcore.ErrorLevel, otellog.SeverityError},
+		{zapcore.DPanicLevel, otellog.SeverityFatal1},
+		{zapcore.PanicLevel, otellog.SeverityFatal1},
+		{zapcore.FatalLevel, otellog.SeverityFatal},
+	}
+
+	for _, tt := range tests {
+		if got := otelSeverityFromZap(tt.level); got != tt.want {
+			t.Fatalf("otelSeverityFromZap(%v) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}